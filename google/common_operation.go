@@ -1,8 +1,10 @@
 package google
 
 import (
+	"context"
 	"fmt"
 	"log"
+	"math/rand"
 	"time"
 
 	"github.com/hashicorp/terraform/helper/resource"
@@ -37,6 +39,112 @@ type Waiter interface {
 	TargetStates() []string
 }
 
+// Canceler is implemented by Waiters whose backing API exposes a way to
+// tear down an in-flight operation. OperationWaitContext calls Cancel when
+// the caller's context is done instead of leaving the operation orphaned
+// in GCP after Terraform gives up on it.
+type Canceler interface {
+	Cancel() error
+}
+
+// BackoffPolicy controls the delay between polls of an in-flight operation.
+// Delay starts at InitialDelay and is multiplied by Multiplier after every
+// poll, capped at MaxDelay, with +/- Jitter fraction of randomness added so
+// that many simultaneous waiters don't all poll in lockstep.
+type BackoffPolicy struct {
+	InitialDelay time.Duration
+	MaxDelay     time.Duration
+	Multiplier   float64
+	Jitter       float64
+}
+
+// defaultBackoffPolicy is used by any Waiter that doesn't implement
+// PollIntervaler: a quick first poll for fast operations, backing off to
+// every 30s for long-running ones.
+var defaultBackoffPolicy = BackoffPolicy{
+	InitialDelay: 1 * time.Second,
+	MaxDelay:     30 * time.Second,
+	Multiplier:   2,
+	Jitter:       0.2,
+}
+
+// next returns the delay to use after the one passed in, applying the
+// policy's multiplier and jitter before clamping to MaxDelay, so jitter can
+// never push the result past the cap.
+func (p BackoffPolicy) next(delay time.Duration) time.Duration {
+	next := time.Duration(float64(delay) * p.Multiplier)
+
+	if p.Jitter > 0 {
+		spread := (rand.Float64()*2 - 1) * p.Jitter
+		next = time.Duration(float64(next) * (1 + spread))
+	}
+
+	if next > p.MaxDelay {
+		next = p.MaxDelay
+	}
+	if next < 0 {
+		next = 0
+	}
+
+	return next
+}
+
+// PollIntervaler is implemented by Waiters that want to override the
+// default exponential backoff used while polling their operation - e.g. a
+// GKE cluster create waiter raising MaxDelay, or a Compute metadata op
+// waiter lowering InitialDelay.
+type PollIntervaler interface {
+	PollInterval() BackoffPolicy
+}
+
+func backoffPolicyFor(w Waiter) BackoffPolicy {
+	if p, ok := w.(PollIntervaler); ok {
+		return p.PollInterval()
+	}
+	return defaultBackoffPolicy
+}
+
+// Progresser is implemented by Waiters that can describe how far along
+// their operation is, beyond a bare pending/done state - e.g. a GKE
+// cluster create reporting its Detail field, or a Compute snapshot
+// reporting its Progress percentage. ok is false when the underlying API
+// hasn't reported anything useful yet.
+type Progresser interface {
+	Progress() (percent int, stage string, ok bool)
+}
+
+// ProgressReporter receives progress updates emitted while OperationWait or
+// OperationWaitContext polls a Waiter that implements Progresser.
+type ProgressReporter interface {
+	Report(activity string, percent int, stage string)
+}
+
+var progressReporter ProgressReporter
+
+// RegisterProgressReporter installs the ProgressReporter used by
+// OperationWait and OperationWaitContext. Pass nil to fall back to
+// log-only progress output.
+func RegisterProgressReporter(r ProgressReporter) {
+	progressReporter = r
+}
+
+func reportProgress(w Waiter, activity string) {
+	p, ok := w.(Progresser)
+	if !ok {
+		return
+	}
+
+	percent, stage, ok := p.Progress()
+	if !ok {
+		return
+	}
+
+	log.Printf("[INFO] %s: %d%% (%s)", activity, percent, stage)
+	if progressReporter != nil {
+		progressReporter.Report(activity, percent, stage)
+	}
+}
+
 type CommonOperationWaiter struct {
 	Op CommonOperation
 }
@@ -79,6 +187,12 @@ func (w *CommonOperationWaiter) TargetStates() []string {
 	return []string{"done: true"}
 }
 
+// Progress is a no-op default: the cloud resource manager operation this
+// waiter wraps has no notion of partial progress.
+func (w *CommonOperationWaiter) Progress() (percent int, stage string, ok bool) {
+	return 0, "", false
+}
+
 func OperationDone(w Waiter) bool {
 	for _, s := range w.TargetStates() {
 		if s == w.State() {
@@ -88,7 +202,13 @@ func OperationDone(w Waiter) bool {
 	return false
 }
 
-func CommonRefreshFunc(w Waiter) resource.StateRefreshFunc {
+// RefreshFunc polls a Waiter once and returns its raw operation, its
+// current State(), and any error encountered doing so. It's an alias for
+// resource.StateRefreshFunc, not a new defined type, so CommonRefreshFunc's
+// result can still be passed directly as a StateChangeConf.Refresh.
+type RefreshFunc = resource.StateRefreshFunc
+
+func CommonRefreshFunc(w Waiter) RefreshFunc {
 	return func() (interface{}, string, error) {
 		// First, read the operation from the server.
 		op, err := w.QueryOp()
@@ -123,7 +243,21 @@ func CommonRefreshFunc(w Waiter) resource.StateRefreshFunc {
 	}
 }
 
+// OperationWait blocks until w reaches one of its TargetStates. It is kept
+// for compatibility with the many existing callers that have no context to
+// plumb through; new code should prefer OperationWaitContext so a Ctrl-C'd
+// apply doesn't keep polling GCP for the full timeout.
 func OperationWait(w Waiter, activity string, timeoutMinutes int) error {
+	return OperationWaitContext(context.Background(), w, activity, time.Duration(timeoutMinutes)*time.Minute)
+}
+
+// OperationWaitContext behaves like OperationWait, but aborts the poll loop
+// as soon as ctx is done - in which case, if w supports it (see Canceler),
+// the in-flight GCP operation is also cancelled rather than left to run to
+// completion on its own. Polling uses w's BackoffPolicy (see PollIntervaler)
+// instead of a fixed interval, so fast operations are caught quickly and
+// slow ones don't hammer the API.
+func OperationWaitContext(ctx context.Context, w Waiter, activity string, timeout time.Duration) error {
 	if OperationDone(w) {
 		if w.Error() != nil {
 			return w.Error()
@@ -131,27 +265,41 @@ func OperationWait(w Waiter, activity string, timeoutMinutes int) error {
 		return nil
 	}
 
-	c := &resource.StateChangeConf{
-		Pending:    w.PendingStates(),
-		Target:     w.TargetStates(),
-		Refresh:    CommonRefreshFunc(w),
-		Timeout:    time.Duration(timeoutMinutes) * time.Minute,
-		MinTimeout: 2 * time.Second,
-	}
-	opRaw, err := c.WaitForState()
-	if err != nil {
-		return fmt.Errorf("Error waiting for %s: %s", activity, err)
-	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
 
-	err = w.SetOp(opRaw)
-	if err != nil {
-		return err
-	}
-	if w.Error() != nil {
-		return w.Error()
-	}
+	refresh := CommonRefreshFunc(w)
+	policy := backoffPolicyFor(w)
+	delay := policy.InitialDelay
 
-	return nil
+	for {
+		_, state, err := refresh()
+		if err != nil {
+			return fmt.Errorf("Error waiting for %s: %s", activity, err)
+		}
+
+		if OperationDone(w) {
+			return nil
+		}
+
+		reportProgress(w, activity)
+
+		log.Printf("[DEBUG] Waiting for %s, operation %s in state %q, next poll in %s", activity, w.OpName(), state, delay)
+
+		select {
+		case <-ctx.Done():
+			if canceler, ok := w.(Canceler); ok {
+				log.Printf("[DEBUG] %s, cancelling operation %s", ctx.Err(), w.OpName())
+				if cancelErr := canceler.Cancel(); cancelErr != nil {
+					log.Printf("[WARN] Error cancelling operation %s: %s", w.OpName(), cancelErr)
+				}
+			}
+			return fmt.Errorf("Error waiting for %s: %s", activity, ctx.Err())
+		case <-time.After(delay):
+		}
+
+		delay = policy.next(delay)
+	}
 }
 
 // The cloud resource manager API operation is an example of one of many
@@ -0,0 +1,101 @@
+package google
+
+import (
+	"fmt"
+	"testing"
+	"time"
+)
+
+func TestBackoffPolicyNext(t *testing.T) {
+	cases := []struct {
+		name    string
+		policy  BackoffPolicy
+		delay   time.Duration
+		minWant time.Duration
+		maxWant time.Duration
+	}{
+		{
+			name:    "grows by multiplier with no jitter",
+			policy:  BackoffPolicy{InitialDelay: time.Second, MaxDelay: 30 * time.Second, Multiplier: 2, Jitter: 0},
+			delay:   2 * time.Second,
+			minWant: 4 * time.Second,
+			maxWant: 4 * time.Second,
+		},
+		{
+			name:    "never exceeds MaxDelay even with positive jitter",
+			policy:  BackoffPolicy{InitialDelay: time.Second, MaxDelay: 10 * time.Second, Multiplier: 2, Jitter: 0.5},
+			delay:   8 * time.Second,
+			minWant: 0,
+			maxWant: 10 * time.Second,
+		},
+		{
+			name:    "jitter stays within the +/- Jitter fraction of the unclamped value",
+			policy:  BackoffPolicy{InitialDelay: time.Second, MaxDelay: time.Minute, Multiplier: 2, Jitter: 0.2},
+			delay:   time.Second,
+			minWant: time.Duration(float64(2*time.Second) * 0.8),
+			maxWant: time.Duration(float64(2*time.Second) * 1.2),
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			// next() is randomized by jitter, so sample it repeatedly to
+			// make sure every draw stays within bounds, not just the mean.
+			for i := 0; i < 50; i++ {
+				got := c.policy.next(c.delay)
+				if got < c.minWant || got > c.maxWant {
+					t.Fatalf("next(%s) = %s, want between %s and %s", c.delay, got, c.minWant, c.maxWant)
+				}
+			}
+		})
+	}
+}
+
+// fakeProgressWaiter lets tests drive reportProgress without a real GCP
+// operation backing it.
+type fakeProgressWaiter struct {
+	CommonOperationWaiter
+	percent int
+	stage   string
+	ok      bool
+}
+
+func (w *fakeProgressWaiter) Progress() (int, string, bool) {
+	return w.percent, w.stage, w.ok
+}
+
+type recordingReporter struct {
+	calls []string
+}
+
+func (r *recordingReporter) Report(activity string, percent int, stage string) {
+	r.calls = append(r.calls, fmt.Sprintf("%s:%d:%s", activity, percent, stage))
+}
+
+func TestReportProgressTransitions(t *testing.T) {
+	reporter := &recordingReporter{}
+	RegisterProgressReporter(reporter)
+	defer RegisterProgressReporter(nil)
+
+	w := &fakeProgressWaiter{}
+
+	// Not yet meaningful: reportProgress must not call the reporter.
+	w.percent, w.stage, w.ok = 0, "", false
+	reportProgress(w, "test")
+
+	w.percent, w.stage, w.ok = 10, "CREATING", true
+	reportProgress(w, "test")
+
+	w.percent, w.stage, w.ok = 50, "CREATING_NODE_POOL", true
+	reportProgress(w, "test")
+
+	want := []string{"test:10:CREATING", "test:50:CREATING_NODE_POOL"}
+	if len(reporter.calls) != len(want) {
+		t.Fatalf("got %d reported calls %v, want %d: %v", len(reporter.calls), reporter.calls, len(want), want)
+	}
+	for i := range want {
+		if reporter.calls[i] != want[i] {
+			t.Errorf("call %d = %q, want %q", i, reporter.calls[i], want[i])
+		}
+	}
+}
@@ -0,0 +1,132 @@
+package google
+
+import (
+	"fmt"
+	"time"
+
+	compute "google.golang.org/api/compute/v1"
+)
+
+// OperationWaitType indicates the scope a Compute Engine operation was
+// created in, which determines which `*Operations.Get` endpoint must be
+// polled to refresh its status.
+type OperationWaitType int
+
+const (
+	OperationWaitGlobal OperationWaitType = iota
+	OperationWaitRegion
+	OperationWaitZone
+)
+
+type ComputeOperationWaiter struct {
+	Service *compute.Service
+	Op      *compute.Operation
+	Project string
+	Type    OperationWaitType
+	Region  string
+	Zone    string
+}
+
+func (w *ComputeOperationWaiter) State() string {
+	if w == nil || w.Op == nil {
+		return "Operation is nil!"
+	}
+
+	return w.Op.Status
+}
+
+func (w *ComputeOperationWaiter) Error() error {
+	if w != nil && w.Op != nil && w.Op.Error != nil && len(w.Op.Error.Errors) > 0 {
+		return ComputeOperationError(*w.Op.Error)
+	}
+	return nil
+}
+
+func (w *ComputeOperationWaiter) SetOp(op interface{}) error {
+	o, ok := op.(*compute.Operation)
+	if !ok {
+		return fmt.Errorf("Unable to set operation, got %#v, expected *compute.Operation", op)
+	}
+
+	w.Op = o
+	return nil
+}
+
+func (w *ComputeOperationWaiter) QueryOp() (interface{}, error) {
+	if w == nil {
+		return nil, fmt.Errorf("Cannot query operation, it's unset or nil.")
+	}
+
+	switch w.Type {
+	case OperationWaitRegion:
+		return w.Service.RegionOperations.Get(w.Project, w.Region, w.Op.Name).Do()
+	case OperationWaitZone:
+		return w.Service.ZoneOperations.Get(w.Project, w.Zone, w.Op.Name).Do()
+	default:
+		return w.Service.GlobalOperations.Get(w.Project, w.Op.Name).Do()
+	}
+}
+
+func (w *ComputeOperationWaiter) OpName() string {
+	if w == nil || w.Op == nil {
+		return "<nil>"
+	}
+
+	return w.Op.Name
+}
+
+func (w *ComputeOperationWaiter) PendingStates() []string {
+	return []string{"PENDING", "RUNNING"}
+}
+
+func (w *ComputeOperationWaiter) TargetStates() []string {
+	return []string{"DONE"}
+}
+
+// PollInterval polls aggressively at first since most Compute operations
+// (instance inserts, metadata updates, attach/detach) finish in a handful
+// of seconds.
+func (w *ComputeOperationWaiter) PollInterval() BackoffPolicy {
+	return BackoffPolicy{
+		InitialDelay: 500 * time.Millisecond,
+		MaxDelay:     10 * time.Second,
+		Multiplier:   1.5,
+		Jitter:       0.2,
+	}
+}
+
+// Progress reports the Progress percentage Compute attaches to most
+// long-running operations (e.g. image imports, large disk resizes). It
+// reports ok=false while the operation is still PENDING and GCP hasn't
+// started reporting a real percentage yet, so callers don't see a stream
+// of "0% (PENDING)" before anything meaningful happens.
+func (w *ComputeOperationWaiter) Progress() (percent int, stage string, ok bool) {
+	if w == nil || w.Op == nil {
+		return 0, "", false
+	}
+
+	if w.Op.Progress == 0 || w.Op.Status == "PENDING" {
+		return 0, "", false
+	}
+
+	return int(w.Op.Progress), w.Op.Status, true
+}
+
+// Cancel deletes the operation resource on GCP's side so a Ctrl-C'd apply
+// doesn't leave an orphaned operation for Terraform to forget about. The
+// Compute API has no true "stop this action" endpoint, so this is the
+// closest equivalent to the Cancel/Delete calls other APIs expose.
+func (w *ComputeOperationWaiter) Cancel() error {
+	if w == nil || w.Op == nil {
+		return nil
+	}
+
+	switch w.Type {
+	case OperationWaitRegion:
+		return w.Service.RegionOperations.Delete(w.Project, w.Region, w.Op.Name).Do()
+	case OperationWaitZone:
+		return w.Service.ZoneOperations.Delete(w.Project, w.Zone, w.Op.Name).Do()
+	default:
+		return w.Service.GlobalOperations.Delete(w.Project, w.Op.Name).Do()
+	}
+}
@@ -0,0 +1,96 @@
+package google
+
+import (
+	"fmt"
+	"time"
+
+	container "google.golang.org/api/container/v1"
+)
+
+type ContainerOperationWaiter struct {
+	Service *container.Service
+	Op      *container.Operation
+	Project string
+	Zone    string
+}
+
+func (w *ContainerOperationWaiter) State() string {
+	if w == nil || w.Op == nil {
+		return "Operation is nil!"
+	}
+
+	return w.Op.Status
+}
+
+func (w *ContainerOperationWaiter) Error() error {
+	if w != nil && w.Op != nil && w.Op.StatusMessage != "" {
+		return fmt.Errorf("Error code %v, message: %s", w.Op.Status, w.Op.StatusMessage)
+	}
+	return nil
+}
+
+func (w *ContainerOperationWaiter) SetOp(op interface{}) error {
+	o, ok := op.(*container.Operation)
+	if !ok {
+		return fmt.Errorf("Unable to set operation, got %#v, expected *container.Operation", op)
+	}
+
+	w.Op = o
+	return nil
+}
+
+func (w *ContainerOperationWaiter) QueryOp() (interface{}, error) {
+	if w == nil {
+		return nil, fmt.Errorf("Cannot query operation, it's unset or nil.")
+	}
+
+	return w.Service.Projects.Zones.Operations.Get(w.Project, w.Zone, w.Op.Name).Do()
+}
+
+func (w *ContainerOperationWaiter) OpName() string {
+	if w == nil || w.Op == nil {
+		return "<nil>"
+	}
+
+	return w.Op.Name
+}
+
+func (w *ContainerOperationWaiter) PendingStates() []string {
+	return []string{"PENDING", "RUNNING"}
+}
+
+func (w *ContainerOperationWaiter) TargetStates() []string {
+	return []string{"DONE"}
+}
+
+// Progress reports the operation's Detail field as its stage - GKE has no
+// percentage, just a human-readable status message like "CREATING_NODE_POOL".
+func (w *ContainerOperationWaiter) Progress() (percent int, stage string, ok bool) {
+	if w == nil || w.Op == nil || w.Op.Detail == "" {
+		return 0, "", false
+	}
+
+	return 0, w.Op.Detail, true
+}
+
+// PollInterval starts and caps higher than the default: cluster and node
+// pool operations routinely take several minutes, so polling every 30s for
+// the whole wait just burns API quota.
+func (w *ContainerOperationWaiter) PollInterval() BackoffPolicy {
+	return BackoffPolicy{
+		InitialDelay: 5 * time.Second,
+		MaxDelay:     60 * time.Second,
+		Multiplier:   2,
+		Jitter:       0.2,
+	}
+}
+
+func containerOperationWait(service *container.Service, op *container.Operation, project, zone, activity string, timeoutMinutes int) error {
+	w := &ContainerOperationWaiter{
+		Service: service,
+		Op:      op,
+		Project: project,
+		Zone:    zone,
+	}
+	return OperationWait(w, activity, timeoutMinutes)
+}
@@ -0,0 +1,65 @@
+package google
+
+import (
+	"fmt"
+
+	dns "google.golang.org/api/dns/v1"
+)
+
+// DnsChangeWaiter polls a ManagedZone change rather than a long-running
+// Operation - the DNS API surfaces change propagation status on the Change
+// resource itself, so there's no separate Operations.Get to call.
+type DnsChangeWaiter struct {
+	Service     *dns.Service
+	Change      *dns.Change
+	Project     string
+	ManagedZone string
+}
+
+func (w *DnsChangeWaiter) State() string {
+	if w == nil || w.Change == nil {
+		return "Change is nil!"
+	}
+
+	return w.Change.Status
+}
+
+func (w *DnsChangeWaiter) Error() error {
+	// The DNS API doesn't surface per-change errors; a failed change simply
+	// never transitions to "done" and the caller's timeout kicks in.
+	return nil
+}
+
+func (w *DnsChangeWaiter) SetOp(op interface{}) error {
+	c, ok := op.(*dns.Change)
+	if !ok {
+		return fmt.Errorf("Unable to set change, got %#v, expected *dns.Change", op)
+	}
+
+	w.Change = c
+	return nil
+}
+
+func (w *DnsChangeWaiter) QueryOp() (interface{}, error) {
+	if w == nil {
+		return nil, fmt.Errorf("Cannot query change, it's unset or nil.")
+	}
+
+	return w.Service.Changes.Get(w.Project, w.ManagedZone, w.Change.Id).Do()
+}
+
+func (w *DnsChangeWaiter) OpName() string {
+	if w == nil || w.Change == nil {
+		return "<nil>"
+	}
+
+	return w.Change.Id
+}
+
+func (w *DnsChangeWaiter) PendingStates() []string {
+	return []string{"pending"}
+}
+
+func (w *DnsChangeWaiter) TargetStates() []string {
+	return []string{"done"}
+}
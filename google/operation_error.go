@@ -0,0 +1,38 @@
+package google
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/go-multierror"
+	compute "google.golang.org/api/compute/v1"
+	sqladmin "google.golang.org/api/sqladmin/v1beta4"
+)
+
+// ComputeOperationError wraps the per-error array embedded in a
+// compute.Operation so callers get every failure GCP reported instead of
+// just the first one, while still behaving like a normal `error`.
+type ComputeOperationError compute.OperationError
+
+func (e ComputeOperationError) Error() string {
+	var result *multierror.Error
+
+	for _, err := range e.Errors {
+		result = multierror.Append(result, fmt.Errorf("Error code %q, message: %s", err.Code, err.Message))
+	}
+
+	return result.Error()
+}
+
+// SqlAdminOperationError mirrors ComputeOperationError for the SQL Admin
+// API's own operation error shape.
+type SqlAdminOperationError sqladmin.OperationErrors
+
+func (e SqlAdminOperationError) Error() string {
+	var result *multierror.Error
+
+	for _, err := range e.Errors {
+		result = multierror.Append(result, fmt.Errorf("Error code %q, message: %s", err.Code, err.Message))
+	}
+
+	return result.Error()
+}
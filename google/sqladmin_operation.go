@@ -0,0 +1,74 @@
+package google
+
+import (
+	"fmt"
+	"time"
+
+	sqladmin "google.golang.org/api/sqladmin/v1beta4"
+)
+
+type SqlAdminOperationWaiter struct {
+	Service *sqladmin.Service
+	Op      *sqladmin.Operation
+	Project string
+}
+
+func (w *SqlAdminOperationWaiter) State() string {
+	if w == nil || w.Op == nil {
+		return "Operation is nil!"
+	}
+
+	return w.Op.Status
+}
+
+func (w *SqlAdminOperationWaiter) Error() error {
+	if w != nil && w.Op != nil && w.Op.Error != nil && len(w.Op.Error.Errors) > 0 {
+		return SqlAdminOperationError(*w.Op.Error)
+	}
+	return nil
+}
+
+func (w *SqlAdminOperationWaiter) SetOp(op interface{}) error {
+	o, ok := op.(*sqladmin.Operation)
+	if !ok {
+		return fmt.Errorf("Unable to set operation, got %#v, expected *sqladmin.Operation", op)
+	}
+
+	w.Op = o
+	return nil
+}
+
+func (w *SqlAdminOperationWaiter) QueryOp() (interface{}, error) {
+	if w == nil {
+		return nil, fmt.Errorf("Cannot query operation, it's unset or nil.")
+	}
+
+	return w.Service.Operations.Get(w.Project, w.Op.Name).Do()
+}
+
+func (w *SqlAdminOperationWaiter) OpName() string {
+	if w == nil || w.Op == nil {
+		return "<nil>"
+	}
+
+	return w.Op.Name
+}
+
+func (w *SqlAdminOperationWaiter) PendingStates() []string {
+	return []string{"PENDING", "RUNNING"}
+}
+
+func (w *SqlAdminOperationWaiter) TargetStates() []string {
+	return []string{"DONE"}
+}
+
+// PollInterval starts and caps a bit higher than the default: instance
+// restores and replica promotions commonly run for several minutes.
+func (w *SqlAdminOperationWaiter) PollInterval() BackoffPolicy {
+	return BackoffPolicy{
+		InitialDelay: 2 * time.Second,
+		MaxDelay:     45 * time.Second,
+		Multiplier:   2,
+		Jitter:       0.2,
+	}
+}
@@ -0,0 +1,66 @@
+package google
+
+import (
+	"fmt"
+
+	storagetransfer "google.golang.org/api/storagetransfer/v1"
+)
+
+// StorageTransferOperationWaiter waits on the long-running Operation
+// returned by TransferJobs.Run - storagetransfer exposes the generic
+// google.longrunning.Operation shape, so Done/Error are read directly off
+// Op rather than a per-API status string.
+type StorageTransferOperationWaiter struct {
+	Service *storagetransfer.Service
+	Op      *storagetransfer.Operation
+	Project string
+}
+
+func (w *StorageTransferOperationWaiter) State() string {
+	if w == nil || w.Op == nil {
+		return "Operation is nil!"
+	}
+
+	return fmt.Sprintf("done: %v", w.Op.Done)
+}
+
+func (w *StorageTransferOperationWaiter) Error() error {
+	if w != nil && w.Op != nil && w.Op.Error != nil {
+		return fmt.Errorf("Error code %v, message: %s", w.Op.Error.Code, w.Op.Error.Message)
+	}
+	return nil
+}
+
+func (w *StorageTransferOperationWaiter) SetOp(op interface{}) error {
+	o, ok := op.(*storagetransfer.Operation)
+	if !ok {
+		return fmt.Errorf("Unable to set operation, got %#v, expected *storagetransfer.Operation", op)
+	}
+
+	w.Op = o
+	return nil
+}
+
+func (w *StorageTransferOperationWaiter) QueryOp() (interface{}, error) {
+	if w == nil {
+		return nil, fmt.Errorf("Cannot query operation, it's unset or nil.")
+	}
+
+	return w.Service.TransferOperations.Get(w.Op.Name).Do()
+}
+
+func (w *StorageTransferOperationWaiter) OpName() string {
+	if w == nil || w.Op == nil {
+		return "<nil>"
+	}
+
+	return w.Op.Name
+}
+
+func (w *StorageTransferOperationWaiter) PendingStates() []string {
+	return []string{"done: false"}
+}
+
+func (w *StorageTransferOperationWaiter) TargetStates() []string {
+	return []string{"done: true"}
+}